@@ -12,14 +12,17 @@
  * - For each changed file, retrieves the original file content from the specified branch using 'git show'
  * - Constructs an LLM reasoning prompt including overall diff and original file contents
  * - Outputs the prompt for further assessment by a large language model
+ * - Optionally submits the prompt directly to an LLM provider via --submit, streaming the
+ *   review to stdout and exiting non-zero if it contains a configured blocker keyword
  *
  * @dependencies
- * - os/exec: to execute git commands
  * - flag: to parse command line arguments
- * - fmt, bytes, strings, log: for various utilities and error handling
+ * - fmt, log, os, path/filepath: for various utilities and error handling
+ * - pkg/clipboard: to copy the constructed prompt to the system clipboard on any platform
+ * - pkg/llm: to submit the prompt to an LLM provider and stream back its review
  *
  * @notes
- * - Assumes that git is installed and that the application is run within a valid git repository.
+ * - The application must be run within a valid git repository.
  * - If a file does not exist in the specified branch, a warning is logged and a placeholder message is used.
  * - Error handling is implemented to gracefully handle command execution failures.
  */
@@ -27,36 +30,102 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/jamesonstone/lmdiff/pkg/clipboard"
 	"github.com/jamesonstone/lmdiff/pkg/diff"
+	"github.com/jamesonstone/lmdiff/pkg/llm"
 	"github.com/jamesonstone/lmdiff/pkg/prompt"
 )
 
 func main() {
-	branch := flag.String("branch", "main", "The branch name to compare changes with (default: main)")
+	branch := flag.String("branch", "main", "Compatibility shim for --working-tree: the branch name to compare the working tree against")
+	fromFlag := flag.String("from", "", "The \"original\" revision to compare from; content shown in the prompt is read from this side")
+	toFlag := flag.String("to", "", "The revision to compare to (used with --from; defaults to HEAD)")
+	rangeFlag := flag.String("range", "", "A revision range: \"A..B\" (two-dot) or \"A...B\" (merge-base)")
+	stagedFlag := flag.Bool("staged", false, "Diff the index against HEAD, like `git diff --staged`")
+	commitFlag := flag.String("commit", "", "Show a single commit's diff, equivalent to --range=<commit>^..<commit>")
+	workingTreeFlag := flag.Bool("working-tree", false, "Diff the current working tree against --branch (the default mode)")
 	copyFlag := flag.Bool("copy", false, "Automatically copy the prompt output to the clipboard")
 	shortCopyFlag := flag.Bool("c", false, "Automatically copy the prompt output to the clipboard (shorthand)")
 	includeUntrackedFlag := flag.Bool("include-untracked", true, "Include untracked files in the analysis (default: true)")
+	clipboardFallbackFile := flag.String("clipboard-fallback-file", filepath.Join(os.TempDir(), "lmdiff-prompt.txt"), "Where to write the prompt if no clipboard tool is available")
+	includeFlag := flag.String("include", "", "Comma-separated glob patterns; when set, only matching files have their content embedded")
+	excludeFlag := flag.String("exclude", "", "Comma-separated glob patterns for files to omit from content embedding (e.g. 'vendor/**,**/*_test.go')")
+	maxFileBytesFlag := flag.Int64("max-file-bytes", 0, "Omit a file's content if it exceeds this many bytes (0 means unlimited)")
+	maxTotalBytesFlag := flag.Int64("max-total-bytes", 0, "Stop embedding file content once this many total bytes have been included (0 means unlimited)")
+	maxTokensFlag := flag.Int("max-tokens", 0, "Approximate token budget for embedded file content; files that don't fit are trimmed to a diff excerpt or structural summary (0 means unlimited)")
+	formatFlag := flag.String("format", "xml", "Output format: xml, markdown, json, or chat")
+	submitFlag := flag.String("submit", "", "Submit the prompt to an LLM provider instead of printing it, and stream the review to stdout, e.g. --submit=openai:gpt-4o (providers: openai, anthropic, generic)")
+	llmBaseURLFlag := flag.String("llm-base-url", "", "Override the provider's base URL (required for --submit=generic:...)")
+	blockerKeywordsFlag := flag.String("blocker-keywords", "", "Comma-separated keywords; if the LLM review contains one (case-insensitive), lmdiff exits non-zero")
 	flag.Parse()
 
-	// Get the git diff relative to the specified branch.
-	gitDiff, err := diff.GetGitDiff(*branch)
+	// Changed-file paths from both backends are repo-root-relative (they come
+	// from `git diff --name-only` / a go-git tree diff), so move to the repo
+	// root before doing any local filesystem access against them. Otherwise
+	// running from a subdirectory silently fails to find files that do exist.
+	if root, err := diff.FindRepoRoot("."); err == nil {
+		if err := os.Chdir(root); err != nil {
+			log.Fatalf("Failed to change to repository root %s: %v", root, err)
+		}
+	}
+
+	fileFilter, err := diff.NewFileFilter(splitPatterns(*includeFlag), splitPatterns(*excludeFlag), *maxFileBytesFlag, *maxTotalBytesFlag)
+	if err != nil {
+		log.Fatalf("Invalid file filter flags: %v", err)
+	}
+
+	formatter, err := prompt.NewFormatter(*formatFlag)
+	if err != nil {
+		log.Fatalf("Invalid --format: %v", err)
+	}
+
+	spec, err := resolveRevisionSpec(*branch, *fromFlag, *toFlag, *rangeFlag, *commitFlag, *stagedFlag, *workingTreeFlag)
+	if err != nil {
+		log.Fatalf("Invalid revision flags: %v", err)
+	}
+
+	// Get the diff for the requested comparison.
+	gitDiff, err := diff.GetGitDiff(spec)
 	if err != nil {
 		log.Fatalf("Failed to get git diff: %v", err)
 	}
 
-	// Get the list of changed (including added) files compared to the specified branch.
-	changedFiles, err := diff.GetChangedFiles(*branch, *includeUntrackedFlag)
+	// Get the list of changed (including added) files for the requested comparison.
+	changedFiles, err := diff.GetChangedFiles(spec, *includeUntrackedFlag)
 	if err != nil {
 		log.Fatalf("Failed to get list of changed files: %v", err)
 	}
 
-	// Map to hold original file contents for each changed file.
+	// Resolve the revision that "original" file content should be read from.
+	// This is spec.From for every mode except ModeMergeBase, where it's the
+	// merge-base commit actually used as the diff's left side.
+	contentRevision, err := diff.GetContentRevision(spec)
+	if err != nil {
+		log.Fatalf("Failed to resolve content revision: %v", err)
+	}
+
+	// Map to hold original file contents for each changed file, and the
+	// reason any file's content was left out instead.
 	originalFiles := make(map[string]string)
+	omittedFiles := make(map[string]string)
+
+	// embedOrOmit applies the file filter to file's content and records the
+	// result in originalFiles or omittedFiles.
+	embedOrOmit := func(file, content string) {
+		if embed, reason := fileFilter.Decide(file, int64(len(content))); embed {
+			originalFiles[file] = content
+		} else {
+			omittedFiles[file] = reason
+		}
+	}
 
 	// Process each file in the changedFiles list
 	for _, file := range changedFiles {
@@ -81,38 +150,140 @@ func main() {
 
 			// Process each file in the directory
 			for _, dirFile := range dirFiles {
-				content := processFile(dirFile, *branch)
-				originalFiles[dirFile] = content
+				embedOrOmit(dirFile, processFile(dirFile, contentRevision))
 			}
 		} else {
 			// It's a regular file
-			content := processFile(file, *branch)
-			originalFiles[file] = content
+			embedOrOmit(file, processFile(file, contentRevision))
 		}
 	}
 
-	// Construct the final prompt.
-	promptText := prompt.ConstructLLMPrompt(gitDiff, changedFiles, originalFiles)
+	// If a token budget is set, pack file contents into it, downgrading
+	// files that don't fit to a diff excerpt or a structural summary.
+	var budget *prompt.Budget
+	truncatedFiles := map[string]string{}
+	if *maxTokensFlag > 0 {
+		budget = prompt.NewBudget(*maxTokensFlag)
+		originalFiles, truncatedFiles = prompt.Pack(budget, gitDiff, originalFiles, prompt.DefaultContextLines)
+	}
+
+	promptData := prompt.Data{
+		Diff:         gitDiff,
+		ChangedFiles: changedFiles,
+		Files:        originalFiles,
+		Omitted:      omittedFiles,
+		Truncated:    truncatedFiles,
+		Budget:       budget,
+	}
+
+	// If --submit is set, send the prompt to an LLM provider and stream its
+	// review to stdout instead of printing the prompt itself.
+	if *submitFlag != "" {
+		submitReview(promptData, *submitFlag, *llmBaseURLFlag, *blockerKeywordsFlag)
+		return
+	}
+
+	// Construct the final prompt in the requested format.
+	promptText := formatter.Format(promptData)
 
 	// Output the constructed prompt.
 	fmt.Println(promptText)
 
-	// If --copy or -c flag is set, copy the prompt output to the clipboard using pbcopy.
+	// If --copy or -c flag is set, copy the prompt output to the clipboard.
 	shouldCopy := *copyFlag || *shortCopyFlag
 	if shouldCopy {
-		cmd := exec.Command("pbcopy")
-		in, err := cmd.StdinPipe()
-		if err != nil {
-			log.Fatalf("Failed to get stdin pipe for pbcopy: %v", err)
+		if err := clipboard.Copy(promptText, *clipboardFallbackFile); err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			fmt.Println("Prompt copied to clipboard.")
+		}
+	}
+}
+
+// submitReview sends promptData to the provider named in submitSpec
+// ("provider:model"), streams the review to stdout, and exits non-zero if
+// the review contains one of blockerKeywords (comma-separated).
+func submitReview(promptData prompt.Data, submitSpec, baseURL, blockerKeywords string) {
+	providerName, model, err := llm.ParseSubmitSpec(submitSpec)
+	if err != nil {
+		log.Fatalf("Invalid --submit: %v", err)
+	}
+
+	provider, err := llm.NewProvider(providerName, baseURL)
+	if err != nil {
+		log.Fatalf("Failed to initialize %s provider: %v", providerName, err)
+	}
+
+	messages := prompt.ChatMessages(promptData)
+	review, err := provider.Stream(context.Background(), model, messages, os.Stdout)
+	fmt.Println()
+	if err != nil {
+		log.Fatalf("LLM submission failed: %v", err)
+	}
+
+	if keyword := llm.FindBlockerKeyword(review, splitPatterns(blockerKeywords)); keyword != "" {
+		fmt.Fprintf(os.Stderr, "Review flagged by blocker keyword %q; failing.\n", keyword)
+		os.Exit(1)
+	}
+}
+
+// resolveRevisionSpec turns the revision-selection flags into a single
+// diff.RevisionSpec. At most one of --from/--to, --range, --staged, or
+// --commit may be given; with none of them (or --working-tree) it falls back
+// to the --branch compatibility shim, diffing the working tree against branch.
+func resolveRevisionSpec(branch, from, to, rangeSpec, commit string, staged, workingTree bool) (diff.RevisionSpec, error) {
+	selected := 0
+	for _, set := range []bool{from != "" || to != "", rangeSpec != "", staged, commit != ""} {
+		if set {
+			selected++
 		}
-		if err := cmd.Start(); err != nil {
-			log.Fatalf("Failed to start pbcopy: %v", err)
+	}
+	if selected > 1 {
+		return diff.RevisionSpec{}, fmt.Errorf("specify only one of --from/--to, --range, --staged, or --commit")
+	}
+
+	switch {
+	case staged:
+		return diff.RevisionSpec{From: "HEAD", Mode: diff.ModeStaged}, nil
+
+	case commit != "":
+		return diff.RevisionSpec{From: commit + "^", To: commit, Mode: diff.ModeTwoDot}, nil
+
+	case rangeSpec != "":
+		if idx := strings.Index(rangeSpec, "..."); idx != -1 {
+			return diff.RevisionSpec{From: rangeSpec[:idx], To: rangeSpec[idx+3:], Mode: diff.ModeMergeBase}, nil
+		}
+		if idx := strings.Index(rangeSpec, ".."); idx != -1 {
+			return diff.RevisionSpec{From: rangeSpec[:idx], To: rangeSpec[idx+2:], Mode: diff.ModeTwoDot}, nil
+		}
+		return diff.RevisionSpec{}, fmt.Errorf("--range must be in the form A..B or A...B")
+
+	case from != "" || to != "":
+		if to == "" {
+			to = "HEAD"
+		}
+		return diff.RevisionSpec{From: from, To: to, Mode: diff.ModeTwoDot}, nil
+
+	default:
+		_ = workingTree // --working-tree is the default; the flag exists for explicitness.
+		return diff.RevisionSpec{From: branch, Mode: diff.ModeWorkingTree}, nil
+	}
+}
+
+// splitPatterns parses a comma-separated flag value into a trimmed slice of
+// glob patterns, returning nil for an empty value.
+func splitPatterns(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	var patterns []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			patterns = append(patterns, part)
 		}
-		in.Write([]byte(promptText))
-		in.Close()
-		cmd.Wait()
-		fmt.Println("Prompt copied to clipboard.")
 	}
+	return patterns
 }
 
 // processFile attempts to get the content of a file from either the branch or locally
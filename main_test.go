@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// TestSubdirectoryRun verifies that running lmdiff from a subdirectory of a
+// repository still finds changed file content, even though changed-file
+// paths are repo-root-relative.
+func TestSubdirectoryRun(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "lmdiff")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = "."
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("building lmdiff: %v\n%s", err, out)
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "main")
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("mkdir sub: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "f.txt"), []byte("original\n"), 0o644); err != nil {
+		t.Fatalf("writing sub/f.txt: %v", err)
+	}
+	runGit(t, dir, "add", "sub/f.txt")
+	runGit(t, dir, "commit", "-m", "initial commit")
+
+	if err := os.WriteFile(filepath.Join(dir, "sub", "f.txt"), []byte("edited\n"), 0o644); err != nil {
+		t.Fatalf("editing sub/f.txt: %v", err)
+	}
+
+	cmd := exec.Command(bin, "--branch=main")
+	cmd.Dir = filepath.Join(dir, "sub")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running lmdiff from subdirectory: %v\n%s", err, out)
+	}
+
+	if strings.Contains(string(out), "could not determine if") {
+		t.Fatalf("expected no directory-lookup warnings, got:\n%s", out)
+	}
+	if !strings.Contains(string(out), "sub/f.txt") {
+		t.Fatalf("expected prompt to include sub/f.txt, got:\n%s", out)
+	}
+	if !strings.Contains(string(out), "original") {
+		t.Fatalf("expected prompt to embed the original file content, got:\n%s", out)
+	}
+}
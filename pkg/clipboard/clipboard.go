@@ -0,0 +1,82 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// Copy writes text to the system clipboard, choosing a platform-appropriate
+// mechanism: pbcopy on macOS, clip.exe (falling back to PowerShell's
+// Set-Clipboard) on Windows, and the first of wl-copy, xclip, or xsel found on
+// PATH on Linux. If no clipboard tool is available, text is instead written to
+// fallbackPath and an error describing why is returned.
+func Copy(text, fallbackPath string) error {
+	cmd, err := commandFor(runtime.GOOS)
+	if err != nil {
+		return writeFallback(text, fallbackPath, err)
+	}
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("error getting stdin pipe for %s: %v", cmd.Path, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting %s: %v", cmd.Path, err)
+	}
+	if _, err := in.Write([]byte(text)); err != nil {
+		return fmt.Errorf("error writing to %s: %v", cmd.Path, err)
+	}
+	in.Close()
+	return cmd.Wait()
+}
+
+// commandFor returns the clipboard command to use on goos, selecting the
+// first available tool in that platform's order of preference.
+func commandFor(goos string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		path, err := exec.LookPath("pbcopy")
+		if err != nil {
+			return nil, fmt.Errorf("pbcopy not found on PATH")
+		}
+		return exec.Command(path), nil
+	case "windows":
+		if path, err := exec.LookPath("clip.exe"); err == nil {
+			return exec.Command(path), nil
+		}
+		if path, err := exec.LookPath("powershell.exe"); err == nil {
+			return exec.Command(path, "-NoProfile", "-Command", "Set-Clipboard"), nil
+		}
+		return nil, fmt.Errorf("neither clip.exe nor powershell.exe found on PATH")
+	case "linux":
+		candidates := [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+		for _, candidate := range candidates {
+			if path, err := exec.LookPath(candidate[0]); err == nil {
+				return exec.Command(path, candidate[1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("none of wl-copy, xclip, or xsel found on PATH")
+	default:
+		return nil, fmt.Errorf("clipboard copy is not supported on %s", goos)
+	}
+}
+
+// writeFallback writes text to fallbackPath when no clipboard tool is
+// available, wrapping the original cause so callers can surface both the
+// reason and where the output landed.
+func writeFallback(text, fallbackPath string, cause error) error {
+	if err := os.MkdirAll(filepath.Dir(fallbackPath), 0o755); err != nil {
+		return fmt.Errorf("no clipboard tool available (%v); error creating fallback directory: %v", cause, err)
+	}
+	if err := os.WriteFile(fallbackPath, []byte(text), 0o644); err != nil {
+		return fmt.Errorf("no clipboard tool available (%v); error writing fallback file %s: %v", cause, fallbackPath, err)
+	}
+	return fmt.Errorf("no clipboard tool available (%v); output written to %s instead", cause, fallbackPath)
+}
@@ -1,47 +1,47 @@
 package diff
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
 )
 
-// GetGitDiff retrieves the git diff against the specified branch.
-func GetGitDiff(branch string) (string, error) {
-	cmd := exec.Command("git", "diff", branch)
-	var out, stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+// GetGitDiff retrieves the diff for the comparison described by spec, using
+// the default Repository backend (see currentRepository) and falling back to
+// the exec backend for modes the default backend doesn't support.
+func GetGitDiff(spec RevisionSpec) (string, error) {
+	repo, err := currentRepository()
 	if err != nil {
-		return "", fmt.Errorf("error executing git diff: %v, %s", err, stderr.String())
+		return "", err
 	}
-	return out.String(), nil
+
+	diffText, err := repo.Diff(spec)
+	if errors.Is(err, ErrUnsupportedMode) {
+		return fallback().Diff(spec)
+	}
+	return diffText, err
 }
 
-// GetChangedFiles returns a slice of filenames that have been changed or added compared to the specified branch.
-// If includeUntracked is true, it also includes untracked files.
-func GetChangedFiles(branch string, includeUntracked bool) ([]string, error) {
-	// Get tracked files with changes
-	cmd := exec.Command("git", "diff", "--name-only", branch)
-	var out, stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+// GetChangedFiles returns a slice of filenames that have been changed or added for the comparison described by spec.
+// If includeUntracked is true and spec is a working-tree comparison, it also includes untracked files; untracked
+// files are meaningless for a historical comparison (--from/--to, --range, --commit) and are omitted there.
+func GetChangedFiles(spec RevisionSpec, includeUntracked bool) ([]string, error) {
+	repo, err := currentRepository()
 	if err != nil {
-		return nil, fmt.Errorf("error getting changed files: %v, %s", err, stderr.String())
+		return nil, err
 	}
-	files := strings.Split(strings.TrimSpace(out.String()), "\n")
-	if len(files) == 1 && files[0] == "" {
-		files = []string{}
+
+	files, err := repo.ChangedFiles(spec)
+	if errors.Is(err, ErrUnsupportedMode) {
+		files, err = fallback().ChangedFiles(spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting changed files: %v", err)
 	}
 
-	// If includeUntracked is true, also add untracked files
-	if includeUntracked {
-		untrackedFiles, err := GetUntrackedFiles()
+	if includeUntracked && spec.Mode == ModeWorkingTree {
+		untrackedFiles, err := repo.UntrackedFiles()
 		if err != nil {
 			return nil, fmt.Errorf("error getting untracked files: %v", err)
 		}
@@ -51,37 +51,39 @@ func GetChangedFiles(branch string, includeUntracked bool) ([]string, error) {
 	return files, nil
 }
 
-// GetUntrackedFiles returns a slice of all untracked files, including files in untracked directories.
-func GetUntrackedFiles() ([]string, error) {
-	// Get all untracked files, including those in untracked directories
-	cmd := exec.Command("git", "ls-files", "--others", "--exclude-standard")
-	var out, stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+// GetContentRevision resolves the revision that should supply "original" file
+// content for spec: the same commit GetGitDiff/GetChangedFiles treat as the
+// comparison's left side. This matters for ModeMergeBase ("A...B" ranges),
+// where that's the merge-base commit rather than the literal A ref.
+func GetContentRevision(spec RevisionSpec) (string, error) {
+	repo, err := currentRepository()
 	if err != nil {
-		return nil, fmt.Errorf("error getting untracked files: %v, %s", err, stderr.String())
+		return "", err
 	}
 
-	files := strings.Split(strings.TrimSpace(out.String()), "\n")
-	if len(files) == 1 && files[0] == "" {
-		return []string{}, nil
+	rev, err := repo.ContentRevision(spec)
+	if errors.Is(err, ErrUnsupportedMode) {
+		return fallback().ContentRevision(spec)
 	}
+	return rev, err
+}
 
-	return files, nil
+// GetUntrackedFiles returns a slice of all untracked files, including files in untracked directories.
+func GetUntrackedFiles() ([]string, error) {
+	repo, err := currentRepository()
+	if err != nil {
+		return nil, err
+	}
+	return repo.UntrackedFiles()
 }
 
-// GetFileContent retrieves the content of a file from the specified branch using git show.
+// GetFileContent retrieves the content of a file from the specified branch.
 func GetFileContent(branch, filename string) (string, error) {
-	cmd := exec.Command("git", "show", fmt.Sprintf("%s:%s", branch, filename))
-	var out, stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+	repo, err := currentRepository()
 	if err != nil {
-		return "", fmt.Errorf("error getting file content for %s from branch %s: %v, %s", filename, branch, err, stderr.String())
+		return "", err
 	}
-	return out.String(), nil
+	return repo.FileContent(branch, filename)
 }
 
 // GetLocalFileContent retrieves the content of a file from the local filesystem.
@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ExecRepository implements Repository by shelling out to the `git` binary on PATH.
+type ExecRepository struct{}
+
+// NewExecRepository returns a Repository backed by the `git` CLI.
+func NewExecRepository() *ExecRepository {
+	return &ExecRepository{}
+}
+
+// Diff retrieves the diff for the comparison described by spec.
+func (r *ExecRepository) Diff(spec RevisionSpec) (string, error) {
+	args, err := diffArgs(spec)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", append([]string{"diff"}, args...)...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error executing git diff: %v, %s", err, stderr.String())
+	}
+	return out.String(), nil
+}
+
+// ChangedFiles returns the tracked files that differ for the comparison described by spec.
+func (r *ExecRepository) ChangedFiles(spec RevisionSpec) ([]string, error) {
+	args, err := diffArgs(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("git", append([]string{"diff", "--name-only"}, args...)...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error getting changed files: %v, %s", err, stderr.String())
+	}
+
+	files := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(files) == 1 && files[0] == "" {
+		files = []string{}
+	}
+	return files, nil
+}
+
+// diffArgs translates a RevisionSpec into the revision arguments `git diff`
+// expects, after its `diff`/`diff --name-only` prefix.
+func diffArgs(spec RevisionSpec) ([]string, error) {
+	switch spec.Mode {
+	case ModeWorkingTree:
+		return []string{spec.From}, nil
+	case ModeTwoDot:
+		return []string{spec.From, spec.To}, nil
+	case ModeMergeBase:
+		return []string{spec.From + "..." + spec.To}, nil
+	case ModeStaged:
+		return []string{"--cached", spec.From}, nil
+	default:
+		return nil, fmt.Errorf("unsupported comparison mode %v", spec.Mode)
+	}
+}
+
+// UntrackedFiles returns all untracked files, including files in untracked directories.
+func (r *ExecRepository) UntrackedFiles() ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "--others", "--exclude-standard")
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("error getting untracked files: %v, %s", err, stderr.String())
+	}
+
+	files := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(files) == 1 && files[0] == "" {
+		return []string{}, nil
+	}
+	return files, nil
+}
+
+// ContentRevision resolves the revision that supplies "original" file content
+// for spec, per the Repository interface doc: spec.From for every mode but
+// ModeMergeBase, and the resolved merge-base commit for ModeMergeBase.
+func (r *ExecRepository) ContentRevision(spec RevisionSpec) (string, error) {
+	if spec.Mode != ModeMergeBase {
+		return spec.From, nil
+	}
+
+	cmd := exec.Command("git", "merge-base", spec.From, spec.To)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error computing merge base of %s and %s: %v, %s", spec.From, spec.To, err, stderr.String())
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// FileContent retrieves the content of a file at the specified revision using git show.
+func (r *ExecRepository) FileContent(rev, path string) (string, error) {
+	cmd := exec.Command("git", "show", fmt.Sprintf("%s:%s", rev, path))
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("error getting file content for %s from revision %s: %v, %s", path, rev, err, stderr.String())
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gobwas/glob"
+)
+
+// FileFilter decides whether a changed file's content should be embedded in
+// the prompt, based on include/exclude globs and per-file/total size caps.
+// Files that are filtered out still appear in the changed files list, but
+// with a reason explaining why their content was left out.
+type FileFilter struct {
+	include       []glob.Glob
+	exclude       []glob.Glob
+	maxFileBytes  int64
+	maxTotalBytes int64
+
+	totalBytes int64
+}
+
+// NewFileFilter compiles include/exclude glob patterns (forward-slash
+// semantics, so patterns like "vendor/**" work on any OS) and returns a
+// FileFilter enforcing them alongside maxFileBytes and maxTotalBytes. A cap of
+// 0 means unlimited.
+func NewFileFilter(include, exclude []string, maxFileBytes, maxTotalBytes int64) (*FileFilter, error) {
+	includeGlobs, err := compileGlobs(include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --include pattern: %v", err)
+	}
+	excludeGlobs, err := compileGlobs(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --exclude pattern: %v", err)
+	}
+
+	return &FileFilter{
+		include:       includeGlobs,
+		exclude:       excludeGlobs,
+		maxFileBytes:  maxFileBytes,
+		maxTotalBytes: maxTotalBytes,
+	}, nil
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("%q: %v", pattern, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// Decide reports whether path should have its content embedded given size in
+// bytes. When it returns false, reason identifies why ("include", "exclude",
+// "size", or "budget") so callers can surface it as an omission attribute.
+// Decide is stateful: accepted sizes accumulate so maxTotalBytes applies
+// across the whole file set, so it must be called at most once per file.
+func (f *FileFilter) Decide(path string, size int64) (embed bool, reason string) {
+	normalized := filepath.ToSlash(path)
+
+	if len(f.include) > 0 && !matchesAny(f.include, normalized) {
+		return false, "include"
+	}
+	if matchesAny(f.exclude, normalized) {
+		return false, "exclude"
+	}
+	if f.maxFileBytes > 0 && size > f.maxFileBytes {
+		return false, "size"
+	}
+	if f.maxTotalBytes > 0 && f.totalBytes+size > f.maxTotalBytes {
+		return false, "budget"
+	}
+
+	f.totalBytes += size
+	return true, ""
+}
+
+func matchesAny(globs []glob.Glob, path string) bool {
+	for _, g := range globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package diff
+
+import "testing"
+
+func TestFileFilter_Decide_Precedence(t *testing.T) {
+	f, err := NewFileFilter([]string{"*.go"}, []string{"*_test.go"}, 10, 15)
+	if err != nil {
+		t.Fatalf("NewFileFilter: %v", err)
+	}
+
+	// Fails --include: doesn't match any include pattern.
+	if embed, reason := f.Decide("README.md", 5); embed || reason != "include" {
+		t.Fatalf("Decide(README.md) = %v, %q; want false, \"include\"", embed, reason)
+	}
+
+	// Matches --include but also --exclude, which takes precedence.
+	if embed, reason := f.Decide("main_test.go", 5); embed || reason != "exclude" {
+		t.Fatalf("Decide(main_test.go) = %v, %q; want false, \"exclude\"", embed, reason)
+	}
+
+	// Matches --include, not --exclude, but exceeds the per-file size cap.
+	if embed, reason := f.Decide("big.go", 20); embed || reason != "size" {
+		t.Fatalf("Decide(big.go) = %v, %q; want false, \"size\"", embed, reason)
+	}
+
+	// Small enough individually, but the first accepted file already spends
+	// toward maxTotalBytes, so the second blows the total budget.
+	if embed, reason := f.Decide("a.go", 10); !embed || reason != "" {
+		t.Fatalf("Decide(a.go) = %v, %q; want true, \"\"", embed, reason)
+	}
+	if embed, reason := f.Decide("b.go", 10); embed || reason != "budget" {
+		t.Fatalf("Decide(b.go) = %v, %q; want false, \"budget\"", embed, reason)
+	}
+}
@@ -0,0 +1,206 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+// GoGitRepository implements Repository as a library on top of go-git,
+// without requiring a `git` binary on PATH.
+type GoGitRepository struct {
+	repo *git.Repository
+}
+
+// NewGoGitRepository opens the git repository rooted at path.
+func NewGoGitRepository(path string) (*GoGitRepository, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening repository at %s: %v", path, err)
+	}
+	return &GoGitRepository{repo: repo}, nil
+}
+
+// FindRepoRoot walks up from dir looking for a .git directory, returning the
+// first ancestor that contains one so lmdiff works from any subdirectory of a
+// repository.
+func FindRepoRoot(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("error resolving absolute path for %s: %v", dir, err)
+	}
+
+	current := abs
+	for {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("no .git directory found above %s", abs)
+		}
+		current = parent
+	}
+}
+
+// resolveRevision resolves rev to a commit, trying it first as given and then
+// as a remote-tracking ref (e.g. "main" falls back to "origin/main") so
+// comparisons work against a branch that only exists on the remote.
+func (r *GoGitRepository) resolveRevision(rev string) (*object.Commit, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		hash, err = r.repo.ResolveRevision(plumbing.Revision("origin/" + rev))
+		if err != nil {
+			return nil, fmt.Errorf("error resolving revision %s: %v", rev, err)
+		}
+	}
+	return r.repo.CommitObject(*hash)
+}
+
+// resolveCommits resolves the two commits to compare for spec: ModeTwoDot
+// compares From against To directly; ModeMergeBase compares the merge-base of
+// From and To against To, matching "A...B" range semantics. ModeWorkingTree
+// and ModeStaged have no commit-pair representation, since they compare
+// against the actual worktree contents or the index rather than two commits,
+// and are reported as unsupported so callers fall back to the exec backend.
+func (r *GoGitRepository) resolveCommits(spec RevisionSpec) (from, to *object.Commit, err error) {
+	switch spec.Mode {
+	case ModeTwoDot:
+		if from, err = r.resolveRevision(spec.From); err != nil {
+			return nil, nil, err
+		}
+		if to, err = r.resolveRevision(spec.To); err != nil {
+			return nil, nil, err
+		}
+		return from, to, nil
+
+	case ModeMergeBase:
+		fromTip, err := r.resolveRevision(spec.From)
+		if err != nil {
+			return nil, nil, err
+		}
+		if to, err = r.resolveRevision(spec.To); err != nil {
+			return nil, nil, err
+		}
+		bases, err := fromTip.MergeBase(to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error computing merge base of %s and %s: %v", spec.From, spec.To, err)
+		}
+		if len(bases) == 0 {
+			return nil, nil, fmt.Errorf("no merge base found between %s and %s", spec.From, spec.To)
+		}
+		return bases[0], to, nil
+
+	default:
+		return nil, nil, ErrUnsupportedMode
+	}
+}
+
+// Diff returns the unified diff for the comparison described by spec.
+func (r *GoGitRepository) Diff(spec RevisionSpec) (string, error) {
+	from, to, err := r.resolveCommits(spec)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := from.Patch(to)
+	if err != nil {
+		return "", fmt.Errorf("error computing patch: %v", err)
+	}
+	return patch.String(), nil
+}
+
+// ChangedFiles returns the files that differ for the comparison described by
+// spec, enumerated from a tree diff rather than a `git diff --name-only` invocation.
+func (r *GoGitRepository) ChangedFiles(spec RevisionSpec) ([]string, error) {
+	from, to, err := r.resolveCommits(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree: %v", err)
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree: %v", err)
+	}
+
+	changes, err := fromTree.Diff(toTree)
+	if err != nil {
+		return nil, fmt.Errorf("error diffing trees: %v", err)
+	}
+
+	var files []string
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("error determining change action: %v", err)
+		}
+		if action == merkletrie.Delete {
+			files = append(files, change.From.Name)
+			continue
+		}
+		files = append(files, change.To.Name)
+	}
+	return files, nil
+}
+
+// UntrackedFiles returns paths in the worktree that git does not track.
+func (r *GoGitRepository) UntrackedFiles() ([]string, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting worktree: %v", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("error getting worktree status: %v", err)
+	}
+
+	var files []string
+	for path, s := range status {
+		if s.Worktree == git.Untracked {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// ContentRevision resolves the revision that supplies "original" file content
+// for spec, per the Repository interface doc: spec.From for every mode but
+// ModeMergeBase, and the resolved merge-base commit for ModeMergeBase.
+func (r *GoGitRepository) ContentRevision(spec RevisionSpec) (string, error) {
+	if spec.Mode != ModeMergeBase {
+		return spec.From, nil
+	}
+	from, _, err := r.resolveCommits(spec)
+	if err != nil {
+		return "", err
+	}
+	return from.Hash.String(), nil
+}
+
+// FileContent returns the content of path as it exists at rev, read directly
+// from the commit's tree blob rather than shelling out to `git show`.
+func (r *GoGitRepository) FileContent(rev, path string) (string, error) {
+	commit, err := r.resolveRevision(rev)
+	if err != nil {
+		return "", err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("error reading tree for %s: %v", rev, err)
+	}
+	file, err := tree.File(path)
+	if err != nil {
+		return "", fmt.Errorf("error getting file content for %s from revision %s: %v", path, rev, err)
+	}
+	return file.Contents()
+}
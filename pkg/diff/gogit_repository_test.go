@@ -0,0 +1,206 @@
+package diff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// newTestRepo initializes a throwaway git repository in a temp dir with one
+// committed file, returning the repo path.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "tracked.txt"), []byte("original\n"), 0o644); err != nil {
+		t.Fatalf("writing tracked.txt: %v", err)
+	}
+	runGit(t, dir, "add", "tracked.txt")
+	runGit(t, dir, "commit", "-m", "initial commit")
+
+	return dir
+}
+
+func TestGoGitRepository_ChangedFiles_ListsDeletions(t *testing.T) {
+	dir := newTestRepo(t)
+	runGit(t, dir, "checkout", "-b", "feature")
+	runGit(t, dir, "rm", "tracked.txt")
+	runGit(t, dir, "commit", "-m", "delete tracked.txt")
+
+	repo, err := NewGoGitRepository(dir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository: %v", err)
+	}
+
+	spec := RevisionSpec{From: "main", To: "feature", Mode: ModeTwoDot}
+	files, err := repo.ChangedFiles(spec)
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if len(files) != 1 || files[0] != "tracked.txt" {
+		t.Fatalf("expected ChangedFiles to list the deleted file, got %v", files)
+	}
+
+	diffText, err := repo.Diff(spec)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if !strings.Contains(diffText, "tracked.txt") {
+		t.Fatalf("expected diff to mention deleted file, got %q", diffText)
+	}
+}
+
+func TestGoGitRepository_ResolveCommits_WorkingTreeUnsupported(t *testing.T) {
+	dir := newTestRepo(t)
+	repo, err := NewGoGitRepository(dir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository: %v", err)
+	}
+
+	spec := RevisionSpec{From: "main", Mode: ModeWorkingTree}
+	if _, err := repo.Diff(spec); err != ErrUnsupportedMode {
+		t.Fatalf("expected Diff to report ErrUnsupportedMode for ModeWorkingTree, got %v", err)
+	}
+	if _, err := repo.ChangedFiles(spec); err != ErrUnsupportedMode {
+		t.Fatalf("expected ChangedFiles to report ErrUnsupportedMode for ModeWorkingTree, got %v", err)
+	}
+}
+
+func TestGetChangedFiles_UncommittedEdit(t *testing.T) {
+	dir := newTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "tracked.txt"), []byte("edited\n"), 0o644); err != nil {
+		t.Fatalf("editing tracked.txt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("untracked\n"), 0o644); err != nil {
+		t.Fatalf("writing scratch.txt: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+
+	defaultRepository = nil
+	fallbackRepository = nil
+	t.Cleanup(func() { defaultRepository = nil; fallbackRepository = nil })
+
+	spec := RevisionSpec{From: "main", Mode: ModeWorkingTree}
+
+	diffText, err := GetGitDiff(spec)
+	if err != nil {
+		t.Fatalf("GetGitDiff: %v", err)
+	}
+	if !strings.Contains(diffText, "tracked.txt") || !strings.Contains(diffText, "edited") {
+		t.Fatalf("expected diff of uncommitted edit to appear, got %q", diffText)
+	}
+
+	files, err := GetChangedFiles(spec, true)
+	if err != nil {
+		t.Fatalf("GetChangedFiles: %v", err)
+	}
+	foundTracked, foundScratch := false, false
+	for _, f := range files {
+		if f == "tracked.txt" {
+			foundTracked = true
+		}
+		if f == "scratch.txt" {
+			foundScratch = true
+		}
+	}
+	if !foundTracked {
+		t.Fatalf("expected changed files to include the uncommitted edit, got %v", files)
+	}
+	if !foundScratch {
+		t.Fatalf("expected changed files to include the untracked file for a working-tree comparison, got %v", files)
+	}
+}
+
+func TestContentRevision_MergeBaseUsesResolvedMergeBase(t *testing.T) {
+	dir := newTestRepo(t)
+	runGit(t, dir, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(dir, "tracked.txt"), []byte("feature-change\n"), 0o644); err != nil {
+		t.Fatalf("writing tracked.txt: %v", err)
+	}
+	runGit(t, dir, "commit", "-am", "feature change")
+
+	runGit(t, dir, "checkout", "main")
+	if err := os.WriteFile(filepath.Join(dir, "tracked.txt"), []byte("main-change-after-branch\n"), 0o644); err != nil {
+		t.Fatalf("writing tracked.txt: %v", err)
+	}
+	runGit(t, dir, "commit", "-am", "main change")
+
+	repo, err := NewGoGitRepository(dir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository: %v", err)
+	}
+
+	spec := RevisionSpec{From: "feature", To: "main", Mode: ModeMergeBase}
+	rev, err := repo.ContentRevision(spec)
+	if err != nil {
+		t.Fatalf("ContentRevision: %v", err)
+	}
+	if rev == "feature" {
+		t.Fatalf("expected ContentRevision to resolve the merge-base commit, got literal ref %q", rev)
+	}
+
+	content, err := repo.FileContent(rev, "tracked.txt")
+	if err != nil {
+		t.Fatalf("FileContent: %v", err)
+	}
+	if content != "original\n" {
+		t.Fatalf("expected content at the merge-base to be %q, got %q", "original\n", content)
+	}
+}
+
+func TestGetChangedFiles_HistoricalComparisonExcludesUntracked(t *testing.T) {
+	dir := newTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("untracked\n"), 0o644); err != nil {
+		t.Fatalf("writing scratch.txt: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+
+	defaultRepository = nil
+	fallbackRepository = nil
+	t.Cleanup(func() { defaultRepository = nil; fallbackRepository = nil })
+
+	spec := RevisionSpec{From: "main", To: "main", Mode: ModeTwoDot}
+	files, err := GetChangedFiles(spec, true)
+	if err != nil {
+		t.Fatalf("GetChangedFiles: %v", err)
+	}
+	for _, f := range files {
+		if f == "scratch.txt" {
+			t.Fatalf("expected historical comparison to exclude untracked scratch file, got %v", files)
+		}
+	}
+}
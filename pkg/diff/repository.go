@@ -0,0 +1,57 @@
+package diff
+
+// Repository abstracts the git operations lmdiff needs (diff, changed files,
+// untracked files, and historical file content) behind a single interface so
+// callers aren't tied to shelling out to a `git` binary on PATH. ExecRepository
+// preserves the original behavior; GoGitRepository implements the same
+// operations as a library on top of go-git.
+type Repository interface {
+	// Diff returns the unified diff for the comparison described by spec.
+	Diff(spec RevisionSpec) (string, error)
+	// ChangedFiles returns the paths that differ for the comparison described by spec.
+	ChangedFiles(spec RevisionSpec) ([]string, error)
+	// UntrackedFiles returns paths that are not tracked by git, excluding ignored files.
+	UntrackedFiles() ([]string, error)
+	// FileContent returns the content of path as it exists at rev.
+	FileContent(rev, path string) (string, error)
+	// ContentRevision resolves the revision that supplies "original" file
+	// content for spec: the same commit Diff/ChangedFiles treat as the
+	// comparison's left side. For every mode but ModeMergeBase that's just
+	// spec.From; for ModeMergeBase it's the resolved merge-base commit, not
+	// the literal ref, since that's what the diff is actually computed from.
+	ContentRevision(spec RevisionSpec) (string, error)
+}
+
+// defaultRepository lazily resolves the Repository implementation used by the
+// package-level Get* functions, preferring the go-git backend and falling back
+// to the exec backend when the working directory isn't a go-git-openable repo.
+var defaultRepository Repository
+
+// fallbackRepository is used when defaultRepository is the go-git backend but
+// returns ErrUnsupportedMode for a particular comparison (e.g. staged diffs),
+// so those calls still work via `git` on PATH rather than failing outright.
+var fallbackRepository Repository
+
+func currentRepository() (Repository, error) {
+	if defaultRepository != nil {
+		return defaultRepository, nil
+	}
+
+	root, err := FindRepoRoot(".")
+	if err == nil {
+		if repo, err := NewGoGitRepository(root); err == nil {
+			defaultRepository = repo
+			return defaultRepository, nil
+		}
+	}
+
+	defaultRepository = NewExecRepository()
+	return defaultRepository, nil
+}
+
+func fallback() Repository {
+	if fallbackRepository == nil {
+		fallbackRepository = NewExecRepository()
+	}
+	return fallbackRepository
+}
@@ -0,0 +1,32 @@
+package diff
+
+import "errors"
+
+// Mode selects how the two sides of a comparison are derived.
+type Mode int
+
+const (
+	// ModeWorkingTree diffs the current working tree against From. To is unused.
+	ModeWorkingTree Mode = iota
+	// ModeTwoDot diffs From's tree directly against To's tree ("A..B" / --from/--to).
+	ModeTwoDot
+	// ModeMergeBase diffs the merge-base of From and To against To ("A...B" range semantics).
+	ModeMergeBase
+	// ModeStaged diffs the index against From (ordinarily HEAD).
+	ModeStaged
+)
+
+// RevisionSpec describes which two points in history (or the working tree)
+// a Repository should compare. From is always the "original" side: the file
+// content shown alongside the diff is read from From (for ModeMergeBase, from
+// the merge-base of From and To; see Repository.ContentRevision), not assumed
+// to be the branch tip, so reviewing a historical commit or range makes sense.
+type RevisionSpec struct {
+	From string
+	To   string
+	Mode Mode
+}
+
+// ErrUnsupportedMode is returned by a Repository implementation that cannot
+// service a RevisionSpec's Mode, so callers can fall back to another backend.
+var ErrUnsupportedMode = errors.New("diff: comparison mode not supported by this backend")
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	defaultAnthropicBaseURL = "https://api.anthropic.com/v1"
+	anthropicVersion        = "2023-06-01"
+	anthropicMaxTokens      = 4096
+)
+
+// AnthropicProvider streams chat completions from the Anthropic Messages API.
+type AnthropicProvider struct {
+	APIKey  string
+	BaseURL string
+}
+
+// NewAnthropicProvider returns a Provider authenticating with
+// ANTHROPIC_API_KEY, POSTing to baseURL (defaulting to the Anthropic API)
+// for message completions.
+func NewAnthropicProvider(baseURL string) (*AnthropicProvider, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY is not set")
+	}
+	if baseURL == "" {
+		baseURL = defaultAnthropicBaseURL
+	}
+	return &AnthropicProvider{APIKey: apiKey, BaseURL: baseURL}, nil
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Text string `json:"text"`
+	} `json:"delta"`
+}
+
+// Stream implements Provider. The Messages API takes the system prompt
+// separately from the conversation, so the leading "system" message (as
+// produced by prompt.ChatMessages) is split out of messages before sending.
+func (p *AnthropicProvider) Stream(ctx context.Context, model string, messages []Message, w io.Writer) (string, error) {
+	system, conversation := splitSystemMessage(messages)
+
+	body, err := json.Marshal(map[string]any{
+		"model":      model,
+		"system":     system,
+		"messages":   conversation,
+		"max_tokens": anthropicMaxTokens,
+		"stream":     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error building request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.APIKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error calling %s: %v", p.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s returned %s: %s", p.BaseURL, resp.Status, string(respBody))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue
+		}
+		if event.Type != "content_block_delta" || event.Delta.Text == "" {
+			continue
+		}
+		io.WriteString(w, event.Delta.Text)
+		full.WriteString(event.Delta.Text)
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("error reading response stream: %v", err)
+	}
+	return full.String(), nil
+}
+
+// splitSystemMessage pulls the first "system" message, if any, out of
+// messages and returns it alongside the remaining conversation.
+func splitSystemMessage(messages []Message) (system string, conversation []anthropicMessage) {
+	for _, m := range messages {
+		if m.Role == "system" && system == "" {
+			system = m.Content
+			continue
+		}
+		conversation = append(conversation, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+	return system, conversation
+}
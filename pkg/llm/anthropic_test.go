@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAnthropicProvider_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("x-api-key"); key != "test-key" {
+			t.Errorf("expected x-api-key header, got %q", key)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: {\"type\":\"content_block_delta\",\"delta\":{\"text\":\"Hello\"}}\n\n")
+		io.WriteString(w, "data: {\"type\":\"content_block_delta\",\"delta\":{\"text\":\", world\"}}\n\n")
+		io.WriteString(w, "data: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer server.Close()
+
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	provider, err := NewAnthropicProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewAnthropicProvider: %v", err)
+	}
+
+	messages := []Message{
+		{Role: "system", Content: "be terse"},
+		{Role: "user", Content: "hi"},
+	}
+
+	var out strings.Builder
+	full, err := provider.Stream(context.Background(), "claude-3", messages, &out)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if full != "Hello, world" {
+		t.Fatalf("expected accumulated text %q, got %q", "Hello, world", full)
+	}
+	if out.String() != full {
+		t.Fatalf("expected streamed output to match accumulated text, got %q", out.String())
+	}
+}
+
+func TestSplitSystemMessage(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "be terse"},
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", Content: "hello"},
+	}
+
+	system, conversation := splitSystemMessage(messages)
+	if system != "be terse" {
+		t.Fatalf("expected system message to be extracted, got %q", system)
+	}
+	if len(conversation) != 2 || conversation[0].Role != "user" || conversation[1].Role != "assistant" {
+		t.Fatalf("expected remaining conversation to exclude the system message, got %v", conversation)
+	}
+}
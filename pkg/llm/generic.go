@@ -0,0 +1,18 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewGenericProvider returns a Provider for any OpenAI-compatible chat
+// completions endpoint (Ollama, vLLM, LM Studio, etc), reusing
+// OpenAIProvider since they share the same wire format. Unlike OpenAI and
+// Anthropic, no credential is required; LLM_API_KEY is sent as a bearer
+// token when set, for self-hosted deployments that enable auth.
+func NewGenericProvider(baseURL string) (*OpenAIProvider, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("--llm-base-url is required for the generic provider")
+	}
+	return &OpenAIProvider{APIKey: os.Getenv("LLM_API_KEY"), BaseURL: baseURL}, nil
+}
@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenericProvider_Stream(t *testing.T) {
+	t.Setenv("LLM_API_KEY", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header when LLM_API_KEY is unset, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: {\"choices\":[{\"delta\":{\"content\":\"ok\"}}]}\n\n")
+		io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider, err := NewGenericProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewGenericProvider: %v", err)
+	}
+
+	var out strings.Builder
+	full, err := provider.Stream(context.Background(), "local-model", []Message{{Role: "user", Content: "hi"}}, &out)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if full != "ok" {
+		t.Fatalf("expected accumulated text %q, got %q", "ok", full)
+	}
+}
+
+func TestNewGenericProvider_RequiresBaseURL(t *testing.T) {
+	if _, err := NewGenericProvider(""); err == nil {
+		t.Fatalf("expected an error when --llm-base-url is not set")
+	}
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIProvider streams chat completions from the OpenAI API, or any
+// OpenAI-compatible endpoint when BaseURL is overridden.
+type OpenAIProvider struct {
+	APIKey  string
+	BaseURL string
+}
+
+// NewOpenAIProvider returns a Provider authenticating with OPENAI_API_KEY,
+// POSTing to baseURL (defaulting to the OpenAI API) for chat completions.
+func NewOpenAIProvider(baseURL string) (*OpenAIProvider, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{APIKey: apiKey, BaseURL: baseURL}, nil
+}
+
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// Stream implements Provider, following the OpenAI chat completions
+// streaming wire format (server-sent "data: {...}" lines terminated by
+// "data: [DONE]").
+func (p *OpenAIProvider) Stream(ctx context.Context, model string, messages []Message, w io.Writer) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":    model,
+		"messages": messages,
+		"stream":   true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error building request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error calling %s: %v", p.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s returned %s: %s", p.BaseURL, resp.Status, string(respBody))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			io.WriteString(w, choice.Delta.Content)
+			full.WriteString(choice.Delta.Content)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("error reading response stream: %v", err)
+	}
+	return full.String(), nil
+}
@@ -0,0 +1,59 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIProvider_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("expected Authorization header, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\n")
+		io.WriteString(w, "data: {\"choices\":[{\"delta\":{\"content\":\", world\"}}]}\n\n")
+		io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	provider, err := NewOpenAIProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider: %v", err)
+	}
+
+	var out strings.Builder
+	full, err := provider.Stream(context.Background(), "gpt-4o", []Message{{Role: "user", Content: "hi"}}, &out)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if full != "Hello, world" {
+		t.Fatalf("expected accumulated text %q, got %q", "Hello, world", full)
+	}
+	if out.String() != full {
+		t.Fatalf("expected streamed output to match accumulated text, got %q", out.String())
+	}
+}
+
+func TestOpenAIProvider_Stream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"error":"invalid key"}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	provider, err := NewOpenAIProvider(server.URL)
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider: %v", err)
+	}
+
+	if _, err := provider.Stream(context.Background(), "gpt-4o", nil, io.Discard); err == nil {
+		t.Fatalf("expected an error for a non-200 response")
+	}
+}
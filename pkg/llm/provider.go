@@ -0,0 +1,39 @@
+// Package llm submits a constructed review prompt to an LLM provider and
+// streams the response, turning lmdiff from a prompt-generator into a
+// reviewer suitable for pre-commit hooks and CI.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jamesonstone/lmdiff/pkg/prompt"
+)
+
+// Message is a single chat message exchanged with a provider, matching the
+// shape prompt.ChatMessages produces.
+type Message = prompt.Message
+
+// Provider streams a chat completion from an LLM backend, writing each
+// chunk of the response to w as it arrives and returning the full
+// accumulated text once the stream completes.
+type Provider interface {
+	Stream(ctx context.Context, model string, messages []Message, w io.Writer) (string, error)
+}
+
+// NewProvider constructs the Provider registered under name, reading
+// credentials from the environment. baseURL overrides the provider's
+// default endpoint and is required for "generic".
+func NewProvider(name, baseURL string) (Provider, error) {
+	switch name {
+	case "openai":
+		return NewOpenAIProvider(baseURL)
+	case "anthropic":
+		return NewAnthropicProvider(baseURL)
+	case "generic":
+		return NewGenericProvider(baseURL)
+	default:
+		return nil, fmt.Errorf("unknown provider %q (want openai, anthropic, or generic)", name)
+	}
+}
@@ -0,0 +1,28 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSubmitSpec splits a --submit flag value of the form "provider:model"
+// into its provider name and model, e.g. "openai:gpt-4o" -> ("openai", "gpt-4o").
+func ParseSubmitSpec(spec string) (providerName, model string, err error) {
+	providerName, model, found := strings.Cut(spec, ":")
+	if !found || providerName == "" || model == "" {
+		return "", "", fmt.Errorf("--submit must be in the form provider:model (e.g. openai:gpt-4o)")
+	}
+	return providerName, model, nil
+}
+
+// FindBlockerKeyword returns the first keyword that appears in review
+// (case-insensitive), or "" if none do.
+func FindBlockerKeyword(review string, keywords []string) string {
+	lower := strings.ToLower(review)
+	for _, keyword := range keywords {
+		if strings.Contains(lower, strings.ToLower(keyword)) {
+			return keyword
+		}
+	}
+	return ""
+}
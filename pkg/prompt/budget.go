@@ -0,0 +1,36 @@
+package prompt
+
+// Budget bounds how many tokens of file content may be embedded in a prompt.
+// Tokens are approximated as bytes/4, a common rough estimate absent a real
+// BPE tokenizer. The diff itself is never subject to the budget; Budget only
+// governs how Pack fits individual file contents.
+type Budget struct {
+	MaxTokens int
+	used      int
+}
+
+// NewBudget returns a Budget capped at maxTokens. A maxTokens of 0 or less
+// means unlimited.
+func NewBudget(maxTokens int) *Budget {
+	return &Budget{MaxTokens: maxTokens}
+}
+
+// EstimateTokens approximates the token count of s as bytes/4.
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// Fits reports whether tokens more tokens can be spent without exceeding MaxTokens.
+func (b *Budget) Fits(tokens int) bool {
+	return b.MaxTokens <= 0 || b.used+tokens <= b.MaxTokens
+}
+
+// Spend records tokens as spent against the budget.
+func (b *Budget) Spend(tokens int) {
+	b.used += tokens
+}
+
+// Used returns the tokens spent so far.
+func (b *Budget) Used() int {
+	return b.used
+}
@@ -0,0 +1,39 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Message is one {role, content} chat message, matching the OpenAI and
+// Anthropic chat completions message shape.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatMessages builds the system/user message pair for data: a system
+// message carrying the review instructions, and a user message carrying the
+// files and diff. ChatFormatter and the --submit LLM integration both
+// operate on this same pair.
+func ChatMessages(data Data) []Message {
+	return []Message{
+		{Role: "system", Content: reviewInstructions},
+		{Role: "user", Content: renderFilesAndDiff(data)},
+	}
+}
+
+// ChatFormatter renders Data as a JSON array of {role, content} messages
+// ready for the OpenAI or Anthropic chat completions APIs: a system message
+// carrying the review instructions, and a user message carrying the files
+// and diff.
+type ChatFormatter struct{}
+
+// Format implements Formatter.
+func (ChatFormatter) Format(data Data) string {
+	out, err := json.MarshalIndent(ChatMessages(data), "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(out)
+}
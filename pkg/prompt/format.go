@@ -0,0 +1,49 @@
+package prompt
+
+import "fmt"
+
+// reviewInstructions is the review guidance given to the LLM, shared across
+// every Formatter.
+const reviewInstructions = "Please analyze the git diff changes. Review the best practices of all files, including new files. Please use KISS+YAGNI+DRY+SOLID principles. Assess the new changes against existing files, suggest improvements, and ask clarifying questions if needed. Complete your review by providing a summary of the changes in paragraph form followed by a bulleted list of suggested changes."
+
+// Data bundles everything a Formatter needs to render a prompt: the diff, the
+// full list of changed files, the content to embed for each, and metadata
+// about what was left out or trimmed.
+//
+// Omitted maps a changed filename to the reason its content was left out of
+// Files entirely (e.g. "exclude", "size", "budget"). Truncated maps a
+// filename whose content in Files is a hunk excerpt or structural summary
+// rather than the full file (e.g. "excerpt", "summary"), as decided by Pack.
+// Budget, if non-nil, reports how much of the token budget was spent. Pass
+// nil/empty maps and a nil Budget when nothing was filtered or packed.
+type Data struct {
+	Diff         string
+	ChangedFiles []string
+	Files        map[string]string
+	Omitted      map[string]string
+	Truncated    map[string]string
+	Budget       *Budget
+}
+
+// Formatter renders Data into a prompt suitable for a particular consumer:
+// a human reviewer, `jq`, or a chat completions API.
+type Formatter interface {
+	Format(data Data) string
+}
+
+// NewFormatter returns the Formatter registered under name. An empty name
+// selects the original XML format.
+func NewFormatter(name string) (Formatter, error) {
+	switch name {
+	case "", "xml":
+		return XMLFormatter{}, nil
+	case "markdown":
+		return MarkdownFormatter{}, nil
+	case "json":
+		return JSONFormatter{}, nil
+	case "chat":
+		return ChatFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want xml, markdown, json, or chat)", name)
+	}
+}
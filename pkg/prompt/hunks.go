@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@`)
+
+// extractHunkContext returns the diff hunks touching name, each preceded by
+// contextLines of the surrounding original content, so a reviewer can see
+// code near a change without embedding the whole file. Returns "" if name has
+// no hunks in diffText.
+func extractHunkContext(diffText, name, original string, contextLines int) string {
+	section := fileDiffSection(diffText, name)
+	if section == "" {
+		return ""
+	}
+
+	originalLines := strings.Split(original, "\n")
+
+	var b strings.Builder
+	for _, line := range strings.Split(section, "\n") {
+		if m := hunkHeaderRe.FindStringSubmatch(line); m != nil {
+			start, _ := strconv.Atoi(m[1])
+			length := 1
+			if m[2] != "" {
+				length, _ = strconv.Atoi(m[2])
+			}
+
+			from := start - 1 - contextLines
+			if from < 0 {
+				from = 0
+			}
+			to := start - 1 + length + contextLines
+			if to > len(originalLines) {
+				to = len(originalLines)
+			}
+
+			fmt.Fprintf(&b, "... context around %s:%d ...\n", name, start)
+			if from < to {
+				b.WriteString(strings.Join(originalLines[from:to], "\n"))
+				b.WriteString("\n")
+			}
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// fileDiffSection extracts the portion of a unified diff belonging to name.
+func fileDiffSection(diffText, name string) string {
+	marker := "diff --git a/" + name + " b/" + name
+	idx := strings.Index(diffText, marker)
+	if idx == -1 {
+		return ""
+	}
+
+	rest := diffText[idx:]
+	if next := strings.Index(rest[len(marker):], "\ndiff --git "); next != -1 {
+		return rest[:len(marker)+next]
+	}
+	return rest
+}
@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonFile is one entry in JSONFormatter's "files" array.
+type jsonFile struct {
+	Name      string `json:"name"`
+	Content   string `json:"content,omitempty"`
+	Truncated string `json:"truncated,omitempty"`
+}
+
+// jsonChangedFile is one entry in JSONFormatter's "changed_files" array.
+type jsonChangedFile struct {
+	Name    string `json:"name"`
+	Omitted string `json:"omitted,omitempty"`
+}
+
+// jsonBudget mirrors Budget for JSON output.
+type jsonBudget struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+// jsonDocument is the top-level shape JSONFormatter emits.
+type jsonDocument struct {
+	Diff         string            `json:"diff"`
+	Files        []jsonFile        `json:"files"`
+	ChangedFiles []jsonChangedFile `json:"changed_files"`
+	Budget       *jsonBudget       `json:"budget,omitempty"`
+}
+
+// JSONFormatter renders Data as structured JSON, for piping into `jq` or
+// other tooling.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(data Data) string {
+	doc := jsonDocument{Diff: data.Diff}
+
+	for _, filename := range data.ChangedFiles {
+		if filename == "" {
+			continue
+		}
+		doc.ChangedFiles = append(doc.ChangedFiles, jsonChangedFile{
+			Name:    filename,
+			Omitted: data.Omitted[filename],
+		})
+	}
+
+	for filename, content := range data.Files {
+		doc.Files = append(doc.Files, jsonFile{
+			Name:      filename,
+			Content:   content,
+			Truncated: data.Truncated[filename],
+		})
+	}
+
+	if data.Budget != nil {
+		doc.Budget = &jsonBudget{Used: data.Budget.Used(), Limit: data.Budget.MaxTokens}
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(out)
+}
@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// MarkdownFormatter renders Data as Markdown: changed files as a bulleted
+// list, file contents as fenced code blocks, and the diff as a ```diff block.
+type MarkdownFormatter struct{}
+
+// Format implements Formatter.
+func (MarkdownFormatter) Format(data Data) string {
+	var b strings.Builder
+	b.WriteString("# Code Review\n\n")
+	b.WriteString(reviewInstructions)
+	b.WriteString("\n\n")
+	b.WriteString(renderFilesAndDiff(data))
+	return b.String()
+}
+
+// renderFilesAndDiff renders the changed files list, file contents, diff, and
+// budget note as Markdown, without the leading instructions. ChatFormatter
+// reuses this for its user message, since the instructions are carried
+// separately in its system message.
+func renderFilesAndDiff(data Data) string {
+	var b strings.Builder
+
+	b.WriteString("## Changed Files\n\n")
+	for _, filename := range data.ChangedFiles {
+		if strings.TrimSpace(filename) == "" {
+			continue
+		}
+		if reason, ok := data.Omitted[filename]; ok {
+			fmt.Fprintf(&b, "- %s (omitted: %s)\n", filename, reason)
+			continue
+		}
+		fmt.Fprintf(&b, "- %s\n", filename)
+	}
+
+	b.WriteString("\n## Files\n")
+	for filename, content := range data.Files {
+		if reason, ok := data.Truncated[filename]; ok {
+			fmt.Fprintf(&b, "\n### %s (truncated: %s)\n\n", filename, reason)
+		} else {
+			fmt.Fprintf(&b, "\n### %s\n\n", filename)
+		}
+		fmt.Fprintf(&b, "```%s\n%s\n```\n", fenceLanguage(filename), content)
+	}
+
+	b.WriteString("\n## Diff\n\n```diff\n")
+	b.WriteString(data.Diff)
+	b.WriteString("\n```\n")
+
+	if data.Budget != nil {
+		fmt.Fprintf(&b, "\n_Budget used: %d/%d tokens_\n", data.Budget.Used(), data.Budget.MaxTokens)
+	}
+
+	return b.String()
+}
+
+// fenceExtensions maps a file extension to the language hint fenceLanguage
+// uses for its fenced code block, so syntax highlighting works in common renderers.
+var fenceExtensions = map[string]string{
+	".go":   "go",
+	".md":   "markdown",
+	".js":   "javascript",
+	".ts":   "typescript",
+	".py":   "python",
+	".rb":   "ruby",
+	".java": "java",
+	".json": "json",
+	".sh":   "bash",
+	".yaml": "yaml",
+	".yml":  "yaml",
+}
+
+func fenceLanguage(filename string) string {
+	return fenceExtensions[filepath.Ext(filename)]
+}
@@ -0,0 +1,49 @@
+package prompt
+
+import "sort"
+
+// DefaultContextLines is how many lines of original content Pack includes
+// around a diff hunk when a file's full content doesn't fit the budget.
+const DefaultContextLines = 3
+
+// Pack fits file contents into budget: full content is added smallest-first
+// until the budget is exhausted; files that don't fit get a hunk excerpt
+// (diffText's hunks for that file plus contextLines of surrounding original
+// content); files for which even the excerpt doesn't fit get a structural
+// summary instead. It returns the content to embed for each file and, for any
+// file not embedded in full, the reason ("excerpt" or "summary").
+func Pack(budget *Budget, diffText string, files map[string]string, contextLines int) (packed map[string]string, truncated map[string]string) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(files[names[i]]) < len(files[names[j]]) })
+
+	packed = make(map[string]string, len(files))
+	truncated = make(map[string]string)
+
+	for _, name := range names {
+		content := files[name]
+		if tokens := EstimateTokens(content); budget.Fits(tokens) {
+			budget.Spend(tokens)
+			packed[name] = content
+			continue
+		}
+
+		if excerpt := extractHunkContext(diffText, name, content, contextLines); excerpt != "" {
+			if tokens := EstimateTokens(excerpt); budget.Fits(tokens) {
+				budget.Spend(tokens)
+				packed[name] = excerpt
+				truncated[name] = "excerpt"
+				continue
+			}
+		}
+
+		summary := Summarize(name, content)
+		budget.Spend(EstimateTokens(summary))
+		packed[name] = summary
+		truncated[name] = "summary"
+	}
+
+	return packed, truncated
+}
@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const packTestDiff = `diff --git a/f.go b/f.go
+index 1111111..2222222 100644
+--- a/f.go
++++ b/f.go
+@@ -10,2 +10,3 @@ func f() {
+ line10
+ line11
++line11.5
+`
+
+// packTestOriginal returns a 20-line file so contextLines can be made to
+// overrun both ends of it.
+func packTestOriginal() string {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = "line" + strconv.Itoa(i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestExtractHunkContext_ClampsAtFileBoundaries(t *testing.T) {
+	original := packTestOriginal() // 20 lines, 1-indexed in the diff
+
+	// contextLines is large enough that both the start and end windows would
+	// run past the file's boundaries; extractHunkContext must clamp instead
+	// of panicking or slicing out of range.
+	got := extractHunkContext(packTestDiff, "f.go", original, 100)
+	if got == "" {
+		t.Fatalf("expected non-empty excerpt for a file with a matching hunk")
+	}
+
+	// No hunk for this name: the diff has no "diff --git a/other.go" section.
+	if got := extractHunkContext(packTestDiff, "other.go", original, 3); got != "" {
+		t.Fatalf("expected empty excerpt for a file with no hunks, got %q", got)
+	}
+}
+
+func TestPack_FallsBackToExcerptThenSummary(t *testing.T) {
+	files := map[string]string{
+		"f.go": "package p\n\nfunc f() {\n" + packTestOriginal() + "\n}\n",
+	}
+
+	// Budget too small even for the hunk excerpt, let alone the full file:
+	// Pack must fall back all the way to a structural summary rather than
+	// returning an oversized or empty result.
+	budget := NewBudget(1)
+	packed, truncated := Pack(budget, packTestDiff, files, DefaultContextLines)
+
+	if truncated["f.go"] != "summary" {
+		t.Fatalf("expected f.go to be truncated to a summary, got %q", truncated["f.go"])
+	}
+	if packed["f.go"] == "" {
+		t.Fatalf("expected a non-empty summary to be packed for f.go")
+	}
+}
+
+func TestPack_FitsFullContentUnderGenerousBudget(t *testing.T) {
+	files := map[string]string{"small.go": "package p\n"}
+
+	budget := NewBudget(1000)
+	packed, truncated := Pack(budget, packTestDiff, files, DefaultContextLines)
+
+	if _, ok := truncated["small.go"]; ok {
+		t.Fatalf("expected small.go to fit in full, got truncated reason %q", truncated["small.go"])
+	}
+	if packed["small.go"] != files["small.go"] {
+		t.Fatalf("expected small.go's full content to be packed unchanged, got %q", packed["small.go"])
+	}
+}
@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// extractors maps a file extension to the function that produces a
+// structural summary for files of that type, so Summarize can be extended to
+// other languages without touching its dispatch logic.
+var extractors = map[string]func(name, content string) string{
+	".go":       summarizeGo,
+	".md":       func(_, content string) string { return summarizeMarkdown(content) },
+	".markdown": func(_, content string) string { return summarizeMarkdown(content) },
+}
+
+// Summarize produces a structural outline of content for name, used when both
+// the full content and a hunk excerpt are too large to fit the budget.
+func Summarize(name, content string) string {
+	if extractor, ok := extractors[filepath.Ext(name)]; ok {
+		return extractor(name, content)
+	}
+	return summarizeGeneric(content)
+}
+
+// summarizeGo lists package, top-level function/method signatures, and type
+// and value declarations, parsed via go/parser rather than regexing the source.
+func summarizeGo(name, content string) string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, content, parser.SkipObjectResolution)
+	if err != nil {
+		return summarizeGeneric(content)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n", file.Name.Name)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			b.WriteString(funcSignature(fset, d))
+			b.WriteString("\n")
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					fmt.Fprintf(&b, "type %s\n", s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						fmt.Fprintf(&b, "%s %s\n", d.Tok, n.Name)
+					}
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// funcSignature renders d's signature (receiver, name, params, results)
+// without its body.
+func funcSignature(fset *token.FileSet, d *ast.FuncDecl) string {
+	sig := &ast.FuncDecl{Recv: d.Recv, Name: d.Name, Type: d.Type}
+	var b strings.Builder
+	if err := printer.Fprint(&b, fset, sig); err != nil {
+		return "func " + d.Name.Name + "(...)"
+	}
+	return b.String()
+}
+
+// summarizeMarkdown lists heading lines only.
+func summarizeMarkdown(content string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "#") {
+			b.WriteString(trimmed)
+			b.WriteString("\n")
+		}
+	}
+	if b.Len() == 0 {
+		return summarizeGeneric(content)
+	}
+	return b.String()
+}
+
+// declKeywords are common top-level declaration openers across mainstream
+// languages, used by summarizeGeneric as a fallback symbol outline for
+// languages without a dedicated extractor.
+var declKeywords = []string{
+	"func ", "function ", "def ", "class ", "struct ", "interface ",
+	"type ", "export ", "public ", "private ", "protected ",
+}
+
+// summarizeGeneric outlines unindented lines that look like top-level
+// declarations, for languages with no dedicated extractor.
+func summarizeGeneric(content string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		for _, kw := range declKeywords {
+			if strings.HasPrefix(trimmed, kw) {
+				b.WriteString(trimmed)
+				b.WriteString("\n")
+				break
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "(no top-level declarations found)"
+	}
+	return b.String()
+}
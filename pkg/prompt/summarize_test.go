@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarize_Go(t *testing.T) {
+	src := `package widgets
+
+type Widget struct {
+	Name string
+}
+
+var DefaultSize = 10
+
+func New(name string) *Widget {
+	return &Widget{Name: name}
+}
+
+func (w *Widget) String() string {
+	return w.Name
+}
+`
+	got := Summarize("widget.go", src)
+
+	for _, want := range []string{"package widgets", "type Widget", "var DefaultSize", "func New(name string) *Widget", "func (w *Widget) String() string"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected summary to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "return") {
+		t.Fatalf("expected summary to omit function bodies, got:\n%s", got)
+	}
+}
+
+func TestSummarize_Markdown(t *testing.T) {
+	src := "# Title\n\nSome body text.\n\n## Section\n\nMore text.\n"
+	got := Summarize("README.md", src)
+
+	if got != "# Title\n## Section\n" {
+		t.Fatalf("expected only heading lines, got %q", got)
+	}
+}
+
+func TestSummarize_GoInvalidSourceFallsBackToGeneric(t *testing.T) {
+	got := Summarize("broken.go", "this is not valid go syntax {{{")
+	if got == "" {
+		t.Fatalf("expected a non-empty fallback summary for unparseable Go source")
+	}
+}
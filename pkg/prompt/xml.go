@@ -0,0 +1,55 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// XMLFormatter renders Data as the original `<prompt>` XML format.
+type XMLFormatter struct{}
+
+// Format implements Formatter.
+func (XMLFormatter) Format(data Data) string {
+	var b strings.Builder
+
+	b.WriteString("<prompt>\n")
+	b.WriteString(". <description>")
+	b.WriteString(reviewInstructions)
+	b.WriteString("</description>\n")
+	b.WriteString("  <changedFiles>\n")
+	for _, filename := range data.ChangedFiles {
+		if strings.TrimSpace(filename) == "" {
+			continue
+		}
+		if reason, ok := data.Omitted[filename]; ok {
+			fmt.Fprintf(&b, "    <file name=\"%s\" omitted=\"%s\"/>\n", filename, reason)
+			continue
+		}
+		fmt.Fprintf(&b, "    <file name=\"%s\"/>\n", filename)
+	}
+	b.WriteString("  </changedFiles>\n")
+	b.WriteString("  <files>\n")
+	for filename, content := range data.Files {
+		if reason, ok := data.Truncated[filename]; ok {
+			fmt.Fprintf(&b, "    <file name=\"%s\" truncated=\"%s\">\n", filename, reason)
+		} else {
+			fmt.Fprintf(&b, "    <file name=\"%s\">\n", filename)
+		}
+		b.WriteString("      <![CDATA[\n")
+		b.WriteString(content)
+		b.WriteString("      ]]>\n")
+		b.WriteString("    </file>\n")
+	}
+	b.WriteString("  </files>\n")
+	b.WriteString("  <gitDiff>\n")
+	b.WriteString("    <![CDATA[\n")
+	b.WriteString(data.Diff)
+	b.WriteString("    ]]>\n")
+	b.WriteString("  </gitDiff>\n")
+	if data.Budget != nil {
+		fmt.Fprintf(&b, "  <budget used=\"%d\" limit=\"%d\"/>\n", data.Budget.Used(), data.Budget.MaxTokens)
+	}
+	b.WriteString("</prompt>\n")
+
+	return b.String()
+}